@@ -3,7 +3,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,6 +19,11 @@ const (
 	deletionsTable = "Deletions"
 )
 
+// minRefreshInterval is the floor for the refresh interval passed to
+// NewIssueCache/NewContactPatcher. Now that push invalidation is available
+// (see notifier.go), callers should pass a much longer interval - an hour
+// or more - and rely on Reload() being triggered by an inbound Notifier
+// event instead of the timer for freshness.
 var minRefreshInterval = time.Minute
 
 // IssueLister is something that can produce a list of all issues.
@@ -110,36 +116,39 @@ type AirtableConfig struct {
 // AirtableClient provides a semantic API to the backend database.
 type AirtableClient struct {
 	client *airtable.Client
+	logger *slog.Logger
 }
 
-func NewAirtableClient(config AirtableConfig) *AirtableClient {
+func NewAirtableClient(config AirtableConfig, opts ...logOption) *AirtableClient {
 	c, _ := airtable.New(config.APIKey, config.BaseID)
-	return &AirtableClient{client: c}
+	return &AirtableClient{client: c, logger: resolveLogger(opts)}
 }
 
 // AllPatches returns a list of contact patches
 func (c *AirtableClient) AllPatches() ([]Patch, error) {
 	// load all additions
+	start := time.Now()
 	var aList []*atPatch
 	err := c.client.ListRecords(additionsTable, &aList, airtable.ListParameters{
 		FilterByFormula: `NOT(NAME = "")`,
 	})
+	observeAirtableCall(additionsTable, start)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load additions, %v", err)
 	}
-
-	for _, p := range aList {
-		log.Printf("found add %s %s %s", p.Name, p.Phone, p.State)
-	}
+	c.logger.Info("loaded additions", "table", additionsTable, "record_count", len(aList), "duration_ms", time.Since(start).Milliseconds())
 
 	// load all deletions
+	start = time.Now()
 	var dList []*atPatch
 	err = c.client.ListRecords(deletionsTable, &dList, airtable.ListParameters{
 		FilterByFormula: `NOT(NAME = "")`,
 	})
+	observeAirtableCall(deletionsTable, start)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load deletions, %v", err)
 	}
+	c.logger.Info("loaded deletions", "table", deletionsTable, "record_count", len(dList), "duration_ms", time.Since(start).Milliseconds())
 
 	var patches []Patch
 	for _, add := range aList {
@@ -158,13 +167,16 @@ func (c *AirtableClient) AllPatches() ([]Patch, error) {
 // AllIssues returns a list of issues with standard contacts, if any, linked to them.
 func (c *AirtableClient) AllIssues() ([]Issue, error) {
 	// load all contacts first
+	start := time.Now()
 	var cList []*atContact
 	err := c.client.ListRecords(contactsTable, &cList, airtable.ListParameters{
 		FilterByFormula: `NOT(NAME = "")`,
 	})
+	observeAirtableCall(contactsTable, start)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load contacts, %v", err)
 	}
+	c.logger.Info("loaded contacts", "table", contactsTable, "record_count", len(cList), "duration_ms", time.Since(start).Milliseconds())
 	// index contacts by ID for easy joins
 	contactsMap := map[string]*atContact{}
 	for _, c := range cList {
@@ -172,6 +184,7 @@ func (c *AirtableClient) AllIssues() ([]Issue, error) {
 	}
 
 	// load all issues
+	start = time.Now()
 	var list []*atIssue
 	err = c.client.ListRecords(issuesTable, &list, airtable.ListParameters{
 		FilterByFormula: `NOT(OR(NAME = "", INACTIVE))`,
@@ -182,9 +195,11 @@ func (c *AirtableClient) AllIssues() ([]Issue, error) {
 			},
 		},
 	})
+	observeAirtableCall(issuesTable, start)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load issues, %v", err)
 	}
+	c.logger.Info("loaded issues", "table", issuesTable, "record_count", len(list), "duration_ms", time.Since(start).Milliseconds())
 	// normalize and join with contacts
 	var ret []Issue
 	for _, i := range list {
@@ -192,7 +207,7 @@ func (c *AirtableClient) AllIssues() ([]Issue, error) {
 		for _, id := range i.ContactLinks {
 			contact := contactsMap[id]
 			if contact == nil {
-				log.Println("[WARN] unable to find contact with ID", id)
+				c.logger.Warn("unable to find contact", "contact_id", id)
 				continue
 			}
 			contacts = append(contacts, contact.toContact())
@@ -202,29 +217,68 @@ func (c *AirtableClient) AllIssues() ([]Issue, error) {
 	return ret, nil
 }
 
+// maxRefreshBackoff caps how long refresh will wait between retries after a
+// run of consecutive failures, so a prolonged Airtable outage doesn't leave
+// the cache checking in only once an hour.
+const maxRefreshBackoff = 10 * time.Minute
+
 // issueCache stores an in-memory copy of the issue list with automatic refresh.
 type issueCache struct {
-	delegate IssueLister
-	stop     chan struct{} // close-only
-	force    chan struct{}
-	val      atomic.Value // of []Issue
-	stopOnce sync.Once
+	delegate     IssueLister
+	snapshotPath string // path to persist/load a JSON snapshot; "" disables it
+	leader       Leader // nil means "always leader", e.g. single-replica deployments
+	follower     FollowerSource
+	logger       *slog.Logger
+	stop         chan struct{} // close-only
+	force        chan struct{}
+	val          atomic.Value // of []Issue
+	lastSuccess  atomic.Value // of time.Time
+	stopOnce     sync.Once
 }
 
-// NewIssueCache returns an issue cache after ensuring that the issue list is loaded.
-func NewIssueCache(delegate IssueLister, refreshInterval time.Duration) (IssueLister, error) {
-	issues, err := delegate.AllIssues()
+// NewIssueCache returns an issue cache after ensuring that the issue list is
+// loaded. If delegate.AllIssues() fails on startup (e.g. Airtable is down)
+// and snapshotPath names a file written by a previous successful reload,
+// that snapshot is loaded instead so the cache can still serve data.
+//
+// leader and follower coordinate so that only one replica of this service
+// polls Airtable when several are running: pass nil for both on a
+// single-replica deployment. When leader is non-nil and this replica isn't
+// currently leading, refresh fetches from follower instead of delegate.
+func NewIssueCache(delegate IssueLister, refreshInterval time.Duration, snapshotPath string, leader Leader, follower FollowerSource, opts ...logOption) (IssueLister, error) {
+	ic := &issueCache{
+		delegate:     delegate,
+		snapshotPath: snapshotPath,
+		leader:       leader,
+		follower:     follower,
+		logger:       resolveLogger(opts),
+		stop:         make(chan struct{}),
+		force:        make(chan struct{}, 1),
+	}
+	var issues []Issue
+	var err error
+	if isLeader(leader) {
+		issues, err = delegate.AllIssues()
+	} else {
+		issues, err = follower.FetchIssues()
+	}
 	if err != nil {
-		return nil, err
+		issues, err = loadIssueSnapshot(snapshotPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load issues and no usable snapshot: %v", err)
+		}
+		ic.logger.Warn("loaded issues from snapshot after Airtable load failed", "cache", "issues", "record_count", len(issues), "path", snapshotPath)
+	} else {
+		ic.lastSuccess.Store(time.Now())
+		if isLeader(leader) {
+			if err := saveSnapshot(snapshotPath, issues); err != nil {
+				ic.logger.Warn("unable to persist issue snapshot", "cache", "issues", "error", err)
+			}
+		}
 	}
 	if refreshInterval <= minRefreshInterval {
 		refreshInterval = minRefreshInterval
 	}
-	ic := &issueCache{
-		delegate: delegate,
-		stop:     make(chan struct{}),
-		force:    make(chan struct{}, 1),
-	}
 	ic.val.Store(issues)
 	go ic.refresh(refreshInterval)
 	return ic, nil
@@ -240,25 +294,58 @@ func (ic *issueCache) Close() error {
 	return nil
 }
 
+// LastSuccessAt returns the time of the most recent successful reload, or
+// the zero Time if the cache has never loaded fresh data from delegate (or,
+// on a follower, from follower).
+func (ic *issueCache) LastSuccessAt() time.Time {
+	t, _ := ic.lastSuccess.Load().(time.Time)
+	return t
+}
+
 func (ic *issueCache) refresh(interval time.Duration) {
-	reload := func() {
-		issues, err := ic.delegate.AllIssues()
+	backoff := interval
+	reload := func() bool {
+		reloadAttemptsTotal.WithLabelValues("issues").Inc()
+		start := time.Now()
+		var issues []Issue
+		var err error
+		if isLeader(ic.leader) {
+			issues, err = ic.delegate.AllIssues()
+		} else {
+			issues, err = ic.follower.FetchIssues()
+		}
 		if err != nil {
-			log.Println("Error loading issues,", err)
+			reloadFailuresTotal.WithLabelValues("issues").Inc()
+			ic.logger.Error("reload failed", "cache", "issues", "error", err, "duration_ms", time.Since(start).Milliseconds())
+			return false
 		}
-		log.Println(len(issues), "issues loaded")
+		reloadSuccessesTotal.WithLabelValues("issues").Inc()
+		ic.logger.Info("reload succeeded", "cache", "issues", "record_count", len(issues), "duration_ms", time.Since(start).Milliseconds())
 		ic.val.Store(issues)
+		ic.lastSuccess.Store(time.Now())
+		if isLeader(ic.leader) {
+			if err := saveSnapshot(ic.snapshotPath, issues); err != nil {
+				ic.logger.Warn("unable to persist issue snapshot", "cache", "issues", "error", err)
+			}
+		}
+		return true
 	}
 	t := time.NewTimer(interval)
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
-			t.Reset(interval)
-			reload()
+			backoff = nextBackoff(backoff, interval, reload())
+			t.Reset(backoff)
 		case <-ic.force:
-			t.Reset(interval)
-			reload()
+			backoff = nextBackoff(backoff, interval, reload())
+			t.Reset(backoff)
+		case <-leaderStep(ic.leader):
+			// Leadership just changed; reload immediately instead of
+			// waiting out the rest of the current interval against a
+			// delegate/follower that's no longer the right one to ask.
+			backoff = nextBackoff(backoff, interval, reload())
+			t.Reset(backoff)
 		case <-ic.stop:
 			return
 		}
@@ -269,29 +356,68 @@ func (ic *issueCache) AllIssues() ([]Issue, error) {
 	return ic.val.Load().([]Issue), nil
 }
 
+// ItemCount returns the number of issues currently held by the cache.
+func (ic *issueCache) ItemCount() int {
+	return len(ic.val.Load().([]Issue))
+}
+
 // patchCache stores an in-memory copy of the issue list with automatic refresh.
 type patchCache struct {
-	delegate ContactPatcher
-	stop     chan struct{} // close-only
-	force    chan struct{}
-	val      atomic.Value // of []Issue
-	stopOnce sync.Once
+	delegate     ContactPatcher
+	snapshotPath string // path to persist/load a JSON snapshot; "" disables it
+	leader       Leader // nil means "always leader", e.g. single-replica deployments
+	follower     FollowerSource
+	logger       *slog.Logger
+	stop         chan struct{} // close-only
+	force        chan struct{}
+	val          atomic.Value // of []Patch
+	lastSuccess  atomic.Value // of time.Time
+	stopOnce     sync.Once
 }
 
-// NewContactPatcher returns an contact patch cache after ensuring that the contact patch list is loaded.
-func NewContactPatcher(delegate ContactPatcher, refreshInterval time.Duration) (ContactPatcher, error) {
-	patches, err := delegate.AllPatches()
+// NewContactPatcher returns an contact patch cache after ensuring that the
+// contact patch list is loaded. If delegate.AllPatches() fails on startup
+// and snapshotPath names a file written by a previous successful reload,
+// that snapshot is loaded instead so the cache can still serve data.
+//
+// leader and follower coordinate so that only one replica of this service
+// polls Airtable when several are running: pass nil for both on a
+// single-replica deployment. When leader is non-nil and this replica isn't
+// currently leading, refresh fetches from follower instead of delegate.
+func NewContactPatcher(delegate ContactPatcher, refreshInterval time.Duration, snapshotPath string, leader Leader, follower FollowerSource, opts ...logOption) (ContactPatcher, error) {
+	pc := &patchCache{
+		delegate:     delegate,
+		snapshotPath: snapshotPath,
+		leader:       leader,
+		follower:     follower,
+		logger:       resolveLogger(opts),
+		stop:         make(chan struct{}),
+		force:        make(chan struct{}, 1),
+	}
+	var patches []Patch
+	var err error
+	if isLeader(leader) {
+		patches, err = delegate.AllPatches()
+	} else {
+		patches, err = follower.FetchPatches()
+	}
 	if err != nil {
-		return nil, err
+		patches, err = loadPatchSnapshot(snapshotPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load patches and no usable snapshot: %v", err)
+		}
+		pc.logger.Warn("loaded patches from snapshot after Airtable load failed", "cache", "patches", "record_count", len(patches), "path", snapshotPath)
+	} else {
+		pc.lastSuccess.Store(time.Now())
+		if isLeader(leader) {
+			if err := saveSnapshot(snapshotPath, patches); err != nil {
+				pc.logger.Warn("unable to persist patch snapshot", "cache", "patches", "error", err)
+			}
+		}
 	}
 	if refreshInterval <= minRefreshInterval {
 		refreshInterval = minRefreshInterval
 	}
-	pc := &patchCache{
-		delegate: delegate,
-		stop:     make(chan struct{}),
-		force:    make(chan struct{}, 1),
-	}
 	pc.val.Store(patches)
 	go pc.refresh(refreshInterval)
 	return pc, nil
@@ -307,25 +433,58 @@ func (pc *patchCache) Close() error {
 	return nil
 }
 
+// LastSuccessAt returns the time of the most recent successful reload, or
+// the zero Time if the cache has never loaded fresh data from delegate (or,
+// on a follower, from follower).
+func (pc *patchCache) LastSuccessAt() time.Time {
+	t, _ := pc.lastSuccess.Load().(time.Time)
+	return t
+}
+
 func (pc *patchCache) refresh(interval time.Duration) {
-	reload := func() {
-		patches, err := pc.delegate.AllPatches()
+	backoff := interval
+	reload := func() bool {
+		reloadAttemptsTotal.WithLabelValues("patches").Inc()
+		start := time.Now()
+		var patches []Patch
+		var err error
+		if isLeader(pc.leader) {
+			patches, err = pc.delegate.AllPatches()
+		} else {
+			patches, err = pc.follower.FetchPatches()
+		}
 		if err != nil {
-			log.Println("Error loading patches,", err)
+			reloadFailuresTotal.WithLabelValues("patches").Inc()
+			pc.logger.Error("reload failed", "cache", "patches", "error", err, "duration_ms", time.Since(start).Milliseconds())
+			return false
 		}
-		log.Println(len(patches), "patches loaded")
+		reloadSuccessesTotal.WithLabelValues("patches").Inc()
+		pc.logger.Info("reload succeeded", "cache", "patches", "record_count", len(patches), "duration_ms", time.Since(start).Milliseconds())
 		pc.val.Store(patches)
+		pc.lastSuccess.Store(time.Now())
+		if isLeader(pc.leader) {
+			if err := saveSnapshot(pc.snapshotPath, patches); err != nil {
+				pc.logger.Warn("unable to persist patch snapshot", "cache", "patches", "error", err)
+			}
+		}
+		return true
 	}
 	t := time.NewTimer(interval)
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
-			t.Reset(interval)
-			reload()
+			backoff = nextBackoff(backoff, interval, reload())
+			t.Reset(backoff)
 		case <-pc.force:
-			t.Reset(interval)
-			reload()
+			backoff = nextBackoff(backoff, interval, reload())
+			t.Reset(backoff)
+		case <-leaderStep(pc.leader):
+			// Leadership just changed; reload immediately instead of
+			// waiting out the rest of the current interval against a
+			// delegate/follower that's no longer the right one to ask.
+			backoff = nextBackoff(backoff, interval, reload())
+			t.Reset(backoff)
 		case <-pc.stop:
 			return
 		}
@@ -335,3 +494,66 @@ func (pc *patchCache) refresh(interval time.Duration) {
 func (pc *patchCache) AllPatches() ([]Patch, error) {
 	return pc.val.Load().([]Patch), nil
 }
+
+// ItemCount returns the number of patches currently held by the cache.
+func (pc *patchCache) ItemCount() int {
+	return len(pc.val.Load().([]Patch))
+}
+
+// nextBackoff computes the delay before the next refresh attempt: back to
+// the base interval on success, doubled (up to maxRefreshBackoff) on each
+// consecutive failure.
+func nextBackoff(current, base time.Duration, succeeded bool) time.Duration {
+	if succeeded {
+		return base
+	}
+	next := current * 2
+	if next > maxRefreshBackoff {
+		next = maxRefreshBackoff
+	}
+	return next
+}
+
+// saveSnapshot writes v to path as JSON. It is a no-op when path is empty.
+func saveSnapshot(path string, v interface{}) error {
+	if path == "" {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// loadIssueSnapshot reads a snapshot previously written by saveSnapshot.
+func loadIssueSnapshot(path string) ([]Issue, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no snapshot path configured")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	if err := json.Unmarshal(b, &issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// loadPatchSnapshot reads a snapshot previously written by saveSnapshot.
+func loadPatchSnapshot(path string) ([]Patch, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no snapshot path configured")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var patches []Patch
+	if err := json.Unmarshal(b, &patches); err != nil {
+		return nil, err
+	}
+	return patches, nil
+}
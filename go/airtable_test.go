@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIssueLister simulates an upstream that fails on demand so we can pin
+// down issueCache's fail-safe semantics.
+type fakeIssueLister struct {
+	mu     sync.Mutex
+	issues []Issue
+	err    error
+	calls  int
+}
+
+func (f *fakeIssueLister) AllIssues() ([]Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.issues, nil
+}
+
+func (f *fakeIssueLister) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeIssueLister) setIssues(issues []Issue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.issues = issues
+	f.err = nil
+}
+
+func waitForCalls(f *fakeIssueLister, n int, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		f.mu.Lock()
+		calls := f.calls
+		f.mu.Unlock()
+		if calls >= n {
+			return true
+		}
+		select {
+		case <-deadline:
+			return false
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestIssueCacheRetainsLastGoodDataOnError(t *testing.T) {
+	initial := []Issue{{ID: "1", Name: "first"}}
+	delegate := &fakeIssueLister{issues: initial}
+
+	cache, err := NewIssueCache(delegate, time.Hour, "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewIssueCache: %v", err)
+	}
+	ic := cache.(*issueCache)
+	defer ic.Close()
+
+	delegate.setErr(errors.New("airtable is down"))
+	ic.Reload()
+	if !waitForCalls(delegate, 2, time.Second) {
+		t.Fatal("reload was never attempted")
+	}
+
+	got, err := cache.AllIssues()
+	if err != nil {
+		t.Fatalf("AllIssues: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("expected stale data to be retained, got %v", got)
+	}
+}
+
+func TestIssueCacheTracksLastSuccessAt(t *testing.T) {
+	delegate := &fakeIssueLister{issues: []Issue{{ID: "1"}}}
+	cache, err := NewIssueCache(delegate, time.Hour, "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewIssueCache: %v", err)
+	}
+	ic := cache.(*issueCache)
+	defer ic.Close()
+
+	before := ic.LastSuccessAt()
+	if before.IsZero() {
+		t.Fatal("expected LastSuccessAt to be set after a successful initial load")
+	}
+
+	delegate.setErr(errors.New("boom"))
+	ic.Reload()
+	waitForCalls(delegate, 2, time.Second)
+	if !ic.LastSuccessAt().Equal(before) {
+		t.Fatal("LastSuccessAt should not advance on a failed reload")
+	}
+
+	delegate.setIssues([]Issue{{ID: "2"}})
+	ic.Reload()
+	waitForCalls(delegate, 3, time.Second)
+	if !ic.LastSuccessAt().After(before) {
+		t.Fatal("LastSuccessAt should advance after a subsequent successful reload")
+	}
+}
+
+func TestNewIssueCacheFallsBackToSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.json")
+
+	good := &fakeIssueLister{issues: []Issue{{ID: "1", Name: "from airtable"}}}
+	warm, err := NewIssueCache(good, time.Hour, path, nil, nil)
+	if err != nil {
+		t.Fatalf("NewIssueCache (warm): %v", err)
+	}
+	warm.(*issueCache).Close()
+
+	broken := &fakeIssueLister{err: errors.New("airtable is down")}
+	cache, err := NewIssueCache(broken, time.Hour, path, nil, nil)
+	if err != nil {
+		t.Fatalf("NewIssueCache should fall back to snapshot, got error: %v", err)
+	}
+	defer cache.(*issueCache).Close()
+
+	got, err := cache.AllIssues()
+	if err != nil {
+		t.Fatalf("AllIssues: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "from airtable" {
+		t.Fatalf("expected snapshot contents to be served, got %v", got)
+	}
+}
+
+func TestNewIssueCacheFailsWithoutDelegateOrSnapshot(t *testing.T) {
+	broken := &fakeIssueLister{err: errors.New("airtable is down")}
+	if _, err := NewIssueCache(broken, time.Hour, "", nil, nil); err == nil {
+		t.Fatal("expected an error when there is no live data and no snapshot")
+	}
+}
+
+// fakeContactPatcher simulates an upstream that fails on demand so we can
+// pin down patchCache's fail-safe semantics, mirroring fakeIssueLister.
+type fakeContactPatcher struct {
+	mu      sync.Mutex
+	patches []Patch
+	err     error
+	calls   int
+}
+
+func (f *fakeContactPatcher) AllPatches() ([]Patch, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.patches, nil
+}
+
+func (f *fakeContactPatcher) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeContactPatcher) setPatches(patches []Patch) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.patches = patches
+	f.err = nil
+}
+
+func waitForPatchCalls(f *fakeContactPatcher, n int, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		f.mu.Lock()
+		calls := f.calls
+		f.mu.Unlock()
+		if calls >= n {
+			return true
+		}
+		select {
+		case <-deadline:
+			return false
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestPatchCacheRetainsLastGoodDataOnError(t *testing.T) {
+	initial := []Patch{{Name: "first"}}
+	delegate := &fakeContactPatcher{patches: initial}
+
+	cache, err := NewContactPatcher(delegate, time.Hour, "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewContactPatcher: %v", err)
+	}
+	pc := cache.(*patchCache)
+	defer pc.Close()
+
+	delegate.setErr(errors.New("airtable is down"))
+	pc.Reload()
+	if !waitForPatchCalls(delegate, 2, time.Second) {
+		t.Fatal("reload was never attempted")
+	}
+
+	got, err := cache.AllPatches()
+	if err != nil {
+		t.Fatalf("AllPatches: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "first" {
+		t.Fatalf("expected stale data to be retained, got %v", got)
+	}
+}
+
+func TestPatchCacheTracksLastSuccessAt(t *testing.T) {
+	delegate := &fakeContactPatcher{patches: []Patch{{Name: "first"}}}
+	cache, err := NewContactPatcher(delegate, time.Hour, "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewContactPatcher: %v", err)
+	}
+	pc := cache.(*patchCache)
+	defer pc.Close()
+
+	before := pc.LastSuccessAt()
+	if before.IsZero() {
+		t.Fatal("expected LastSuccessAt to be set after a successful initial load")
+	}
+
+	delegate.setErr(errors.New("boom"))
+	pc.Reload()
+	waitForPatchCalls(delegate, 2, time.Second)
+	if !pc.LastSuccessAt().Equal(before) {
+		t.Fatal("LastSuccessAt should not advance on a failed reload")
+	}
+
+	delegate.setPatches([]Patch{{Name: "second"}})
+	pc.Reload()
+	waitForPatchCalls(delegate, 3, time.Second)
+	if !pc.LastSuccessAt().After(before) {
+		t.Fatal("LastSuccessAt should advance after a subsequent successful reload")
+	}
+}
+
+func TestNewContactPatcherFallsBackToSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patches.json")
+
+	good := &fakeContactPatcher{patches: []Patch{{Name: "from airtable"}}}
+	warm, err := NewContactPatcher(good, time.Hour, path, nil, nil)
+	if err != nil {
+		t.Fatalf("NewContactPatcher (warm): %v", err)
+	}
+	warm.(*patchCache).Close()
+
+	broken := &fakeContactPatcher{err: errors.New("airtable is down")}
+	cache, err := NewContactPatcher(broken, time.Hour, path, nil, nil)
+	if err != nil {
+		t.Fatalf("NewContactPatcher should fall back to snapshot, got error: %v", err)
+	}
+	defer cache.(*patchCache).Close()
+
+	got, err := cache.AllPatches()
+	if err != nil {
+		t.Fatalf("AllPatches: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "from airtable" {
+		t.Fatalf("expected snapshot contents to be served, got %v", got)
+	}
+}
+
+func TestNewContactPatcherFailsWithoutDelegateOrSnapshot(t *testing.T) {
+	broken := &fakeContactPatcher{err: errors.New("airtable is down")}
+	if _, err := NewContactPatcher(broken, time.Hour, "", nil, nil); err == nil {
+		t.Fatal("expected an error when there is no live data and no snapshot")
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	base := time.Minute
+	if got := nextBackoff(base, base, true); got != base {
+		t.Fatalf("success should reset to base interval, got %v", got)
+	}
+	if got := nextBackoff(base, base, false); got != 2*base {
+		t.Fatalf("first failure should double the interval, got %v", got)
+	}
+	if got := nextBackoff(maxRefreshBackoff, base, false); got != maxRefreshBackoff {
+		t.Fatalf("backoff should not exceed maxRefreshBackoff, got %v", got)
+	}
+}
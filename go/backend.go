@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BackendConfig selects and configures which data source issues, contacts,
+// and patches are loaded from.
+type BackendConfig struct {
+	Kind string // "airtable" (default), "file", or "git"
+
+	Airtable AirtableConfig
+	File     FileBackendConfig
+	Git      GitBackendConfig
+}
+
+// backend is what both AirtableClient and FileBackend implement: something
+// that can produce both issues and patches.
+type backend interface {
+	IssueLister
+	ContactPatcher
+}
+
+// NewBackend builds the backend selected by config.Kind.
+func NewBackend(config BackendConfig, opts ...logOption) (backend, error) {
+	switch config.Kind {
+	case "", "airtable":
+		return NewAirtableClient(config.Airtable, opts...), nil
+	case "file":
+		return NewFileBackend(config.File.Dir), nil
+	case "git":
+		return NewGitBackend(config.Git)
+	default:
+		return nil, fmt.Errorf("unknown backend kind %q", config.Kind)
+	}
+}
+
+// FileBackendConfig configures a FileBackend.
+type FileBackendConfig struct {
+	Dir string // directory containing issues.(json|yaml|yml) and patches.(json|yaml|yml)
+}
+
+// FileBackend reads issues and patches from a directory of YAML or JSON
+// files with the same shape as Issue and Patch, instead of from Airtable.
+// This keeps local development working without Airtable credentials and
+// lets the canonical dataset live in a plain directory that can be
+// reviewed like any other change.
+type FileBackend struct {
+	Dir string
+}
+
+// NewFileBackend returns a FileBackend reading from dir.
+func NewFileBackend(dir string) *FileBackend {
+	return &FileBackend{Dir: dir}
+}
+
+// AllIssues implements IssueLister by reading issues.(json|yaml|yml) from
+// Dir. Each issue carries its contacts inline, so no separate join step is
+// needed the way AirtableClient.AllIssues has to join against Contact.
+func (b *FileBackend) AllIssues() ([]Issue, error) {
+	var issues []Issue
+	if err := readRecords(filepath.Join(b.Dir, "issues"), &issues); err != nil {
+		return nil, fmt.Errorf("unable to load issues from %s: %v", b.Dir, err)
+	}
+	return issues, nil
+}
+
+// AllPatches implements ContactPatcher by reading patches.(json|yaml|yml)
+// from Dir.
+func (b *FileBackend) AllPatches() ([]Patch, error) {
+	var patches []Patch
+	if err := readRecords(filepath.Join(b.Dir, "patches"), &patches); err != nil {
+		return nil, fmt.Errorf("unable to load patches from %s: %v", b.Dir, err)
+	}
+	return patches, nil
+}
+
+// readRecords looks for base+".json", base+".yaml", then base+".yml", in
+// that order, and decodes the first one it finds into out.
+func readRecords(base string, out interface{}) error {
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		path := base + ext
+		b, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if ext == ".json" {
+			return json.Unmarshal(b, out)
+		}
+		return yaml.Unmarshal(b, out)
+	}
+	return fmt.Errorf("no .json, .yaml, or .yml file found for %s", base)
+}
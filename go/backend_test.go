@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBackendReadsJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "issues.json"), `[{"ID":"1","Name":"Call your rep","Contacts":[{"ID":"c1","Name":"Rep"}]}]`)
+	writeFile(t, filepath.Join(dir, "patches.json"), `[{"Name":"Someone","Phone":"555-1234","Type":"ADD"}]`)
+
+	b := NewFileBackend(dir)
+	issues, err := b.AllIssues()
+	if err != nil {
+		t.Fatalf("AllIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Name != "Call your rep" || len(issues[0].Contacts) != 1 {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+
+	patches, err := b.AllPatches()
+	if err != nil {
+		t.Fatalf("AllPatches: %v", err)
+	}
+	if len(patches) != 1 || patches[0].Type != "ADD" {
+		t.Fatalf("unexpected patches: %+v", patches)
+	}
+}
+
+func TestFileBackendReadsYAML(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "issues.yaml"), "- id: \"1\"\n  name: Call your rep\n")
+	writeFile(t, filepath.Join(dir, "patches.yaml"), "- name: Someone\n  type: DELETE\n")
+
+	b := NewFileBackend(dir)
+	issues, err := b.AllIssues()
+	if err != nil {
+		t.Fatalf("AllIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Name != "Call your rep" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+
+	patches, err := b.AllPatches()
+	if err != nil {
+		t.Fatalf("AllPatches: %v", err)
+	}
+	if len(patches) != 1 || patches[0].Type != "DELETE" {
+		t.Fatalf("unexpected patches: %+v", patches)
+	}
+}
+
+func TestFileBackendMissingFile(t *testing.T) {
+	b := NewFileBackend(t.TempDir())
+	if _, err := b.AllIssues(); err == nil {
+		t.Fatal("expected an error when no issues file exists")
+	}
+}
+
+func TestNewBackendSelectsByKind(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "issues.json"), `[]`)
+	writeFile(t, filepath.Join(dir, "patches.json"), `[]`)
+
+	b, err := NewBackend(BackendConfig{Kind: "file", File: FileBackendConfig{Dir: dir}})
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	if _, ok := b.(*FileBackend); !ok {
+		t.Fatalf("expected a *FileBackend, got %T", b)
+	}
+
+	if _, err := NewBackend(BackendConfig{Kind: "bogus"}); err == nil {
+		t.Fatal("expected an error for an unknown backend kind")
+	}
+}
+
+func TestExportWritesFileBackendFormat(t *testing.T) {
+	src := &fakeBackend{
+		issues:  []Issue{{ID: "1", Name: "Call your rep"}},
+		patches: []Patch{{Name: "Someone", Type: "ADD"}},
+	}
+	dir := t.TempDir()
+	if err := Export(src, dir); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	b := NewFileBackend(dir)
+	issues, err := b.AllIssues()
+	if err != nil {
+		t.Fatalf("AllIssues after export: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "1" {
+		t.Fatalf("unexpected exported issues: %+v", issues)
+	}
+}
+
+// fakeBackend is a canned backend for exercising Export.
+type fakeBackend struct {
+	issues  []Issue
+	patches []Patch
+}
+
+func (f *fakeBackend) AllIssues() ([]Issue, error)  { return f.issues, nil }
+func (f *fakeBackend) AllPatches() ([]Patch, error) { return f.patches, nil }
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("writeFile(%s): %v", path, err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Export dumps everything delegate can list into dir as issues.json and
+// patches.json, in the format FileBackend/GitBackend expect. It's the
+// guts of the 5calls-export CLI below, factored out so it can also be
+// exercised directly in tests.
+func Export(delegate backend, dir string) error {
+	issues, err := delegate.AllIssues()
+	if err != nil {
+		return fmt.Errorf("unable to load issues: %v", err)
+	}
+	if err := writeRecords(filepath.Join(dir, "issues.json"), issues); err != nil {
+		return fmt.Errorf("unable to write issues: %v", err)
+	}
+
+	patches, err := delegate.AllPatches()
+	if err != nil {
+		return fmt.Errorf("unable to load patches: %v", err)
+	}
+	if err := writeRecords(filepath.Join(dir, "patches.json"), patches); err != nil {
+		return fmt.Errorf("unable to write patches: %v", err)
+	}
+	return nil
+}
+
+func writeRecords(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// main is the entrypoint for 5calls-export: a CLI that dumps the current
+// Airtable state into the FileBackend format, so an existing deployment
+// can switch to `backend: file` or `backend: git` without losing data.
+func main() {
+	out := flag.String("out", "./data", "directory to write issues.json and patches.json into")
+	flag.Parse()
+
+	baseID := os.Getenv("AIRTABLE_BASE_ID")
+	apiKey := os.Getenv("AIRTABLE_API_KEY")
+	if baseID == "" || apiKey == "" {
+		fmt.Fprintln(os.Stderr, "AIRTABLE_BASE_ID and AIRTABLE_API_KEY must be set")
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create output directory:", err)
+		os.Exit(1)
+	}
+
+	client := NewAirtableClient(AirtableConfig{BaseID: baseID, APIKey: apiKey})
+	if err := Export(client, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "export failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println("exported issues and patches to", *out)
+}
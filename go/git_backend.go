@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// GitBackendConfig configures a GitBackend.
+type GitBackendConfig struct {
+	RepoDir string // local clone to pull into and read from
+	Remote  string // remote to pull from; defaults to "origin"
+	Branch  string // branch to pull; defaults to the clone's current branch
+}
+
+// GitBackend wraps a FileBackend, running `git pull` against a local clone
+// before every AllIssues()/AllPatches() call, so the canonical dataset can
+// live in a git repository and be reviewed via pull requests instead of
+// edited directly in Airtable.
+type GitBackend struct {
+	FileBackend
+	config GitBackendConfig
+}
+
+// NewGitBackend returns a GitBackend that pulls and reads from
+// config.RepoDir, which must already be a clone of the dataset repository.
+func NewGitBackend(config GitBackendConfig) (*GitBackend, error) {
+	if config.RepoDir == "" {
+		return nil, fmt.Errorf("git backend: RepoDir is required")
+	}
+	return &GitBackend{
+		FileBackend: FileBackend{Dir: config.RepoDir},
+		config:      config,
+	}, nil
+}
+
+// pull runs `git pull` in the local clone from the configured remote/branch.
+// It is not restricted to fast-forwards: git's own pull settings decide
+// whether diverged history is merged, rebased or refused.
+func (b *GitBackend) pull() error {
+	remote := b.config.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	args := []string{"-C", b.config.RepoDir, "pull", remote}
+	if b.config.Branch != "" {
+		args = append(args, b.config.Branch)
+	}
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git pull failed: %v: %s", err, out)
+	}
+	return nil
+}
+
+func (b *GitBackend) AllIssues() ([]Issue, error) {
+	if err := b.pull(); err != nil {
+		return nil, err
+	}
+	return b.FileBackend.AllIssues()
+}
+
+func (b *GitBackend) AllPatches() ([]Patch, error) {
+	if err := b.pull(); err != nil {
+		return nil, err
+	}
+	return b.FileBackend.AllPatches()
+}
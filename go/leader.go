@@ -0,0 +1,80 @@
+package main
+
+import "log"
+
+// Leader arbitrates which replica of this service is allowed to poll
+// Airtable when several are running behind a load balancer. Without it,
+// every replica independently hits the Issues list/Contact/Additions/
+// Deletions tables on its own timer, multiplying API usage by the replica
+// count. issueCache.refresh and patchCache.refresh only call their delegate
+// when IsLeader() is true; see snapshot.go for how followers get the data
+// instead.
+type Leader interface {
+	// IsLeader reports whether this replica currently holds leadership.
+	IsLeader() bool
+	// Step returns a channel that receives a value every time leadership
+	// changes (gained or lost), so callers can react without polling
+	// IsLeader on a timer.
+	Step() <-chan struct{}
+	// Resign gives up leadership. Implementations should attempt a
+	// graceful handoff to a waiting follower before returning, so the
+	// cluster doesn't have to sit through a full lease expiry to promote
+	// a new leader.
+	Resign() error
+}
+
+// soloLeader is the no-op Leader for local dev and single-replica
+// deployments: it is always the leader and leadership never changes.
+type soloLeader struct {
+	step chan struct{}
+}
+
+// NewSoloLeader returns a Leader suitable for a deployment with exactly one
+// replica, where coordinating leadership would be pure overhead.
+func NewSoloLeader() Leader {
+	return &soloLeader{step: make(chan struct{})}
+}
+
+func (*soloLeader) IsLeader() bool          { return true }
+func (l *soloLeader) Step() <-chan struct{} { return l.step }
+func (*soloLeader) Resign() error           { return nil }
+
+// isLeader reports whether l is nil (single-replica deployments, which are
+// always their own leader) or, when non-nil, whether it currently holds
+// leadership.
+func isLeader(l Leader) bool {
+	return l == nil || l.IsLeader()
+}
+
+// leaderStep returns l.Step(), or a channel that never fires when l is nil,
+// so callers can select on it unconditionally the same way they do isLeader.
+func leaderStep(l Leader) <-chan struct{} {
+	if l == nil {
+		return nil
+	}
+	return l.Step()
+}
+
+// handoffAttempts bounds how many times a coordinated Leader backend tries a
+// graceful transfer before falling back to letting its lease simply expire,
+// modeled on Raft's TransferLeadership: a few retries, each logged, rather
+// than an unbounded retry loop or an immediate bail-out. Retries run back to
+// back, with no delay between them.
+const handoffAttempts = 3
+
+// attemptHandoff runs transfer up to handoffAttempts times, logging each
+// attempt, and returns nil on the first success. If every attempt fails it
+// returns the last error so the caller can fall back to lease expiry.
+func attemptHandoff(transfer func() error) error {
+	var err error
+	for i := 1; i <= handoffAttempts; i++ {
+		log.Println("leader: attempting graceful handoff, try", i, "of", handoffAttempts)
+		if err = transfer(); err == nil {
+			log.Println("leader: handoff succeeded on try", i)
+			return nil
+		}
+		log.Println("leader: handoff attempt", i, "failed,", err)
+	}
+	log.Println("leader: all handoff attempts failed, falling back to lease expiry:", err)
+	return err
+}
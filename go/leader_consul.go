@@ -0,0 +1,68 @@
+//go:build consul
+
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+// consulLeaderKey is the KV path used for the leader election session.
+const consulLeaderKey = "5calls/leader"
+
+// consulLeader is a Leader backed by a Consul session lock: the replica
+// that successfully acquires consulLeaderKey is the leader until it
+// releases the lock or its session expires.
+type consulLeader struct {
+	client   *consulapi.Client
+	lock     *consulapi.Lock
+	leaderCh <-chan struct{}
+	step     chan struct{}
+	isLeader atomic.Bool
+}
+
+// NewConsulLeader campaigns for leadership against the Consul agent at addr
+// and blocks until the lock has been acquired or an error occurs.
+func NewConsulLeader(addr string) (Leader, error) {
+	client, err := consulapi.NewClient(&consulapi.Config{Address: addr})
+	if err != nil {
+		return nil, err
+	}
+	lock, err := client.LockKey(consulLeaderKey)
+	if err != nil {
+		return nil, err
+	}
+	cl := &consulLeader{client: client, lock: lock, step: make(chan struct{})}
+	leaderCh, err := lock.Lock(nil)
+	if err != nil {
+		return nil, err
+	}
+	cl.leaderCh = leaderCh
+	cl.isLeader.Store(leaderCh != nil)
+	go cl.watch()
+	return cl, nil
+}
+
+func (c *consulLeader) watch() {
+	<-c.leaderCh
+	c.isLeader.Store(false)
+	select {
+	case c.step <- struct{}{}:
+	default:
+	}
+}
+
+func (c *consulLeader) IsLeader() bool        { return c.isLeader.Load() }
+func (c *consulLeader) Step() <-chan struct{} { return c.step }
+
+// Resign releases the Consul lock and then waits briefly, giving a waiting
+// follower a window to acquire it before Resign returns.
+func (c *consulLeader) Resign() error {
+	return attemptHandoff(func() error {
+		err := c.lock.Unlock()
+		time.Sleep(100 * time.Millisecond)
+		return err
+	})
+}
@@ -0,0 +1,70 @@
+//go:build etcd
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// etcdLeaderPrefix is the election prefix used for the leader campaign.
+const etcdLeaderPrefix = "/5calls/leader"
+
+// etcdLeader is a Leader backed by an etcd concurrency.Election: the
+// replica whose Campaign call returns first holds leadership until its
+// session closes or Resign is called.
+type etcdLeader struct {
+	client   *clientv3.Client
+	session  *concurrency.Session
+	election *concurrency.Election
+	step     chan struct{}
+	isLeader atomic.Bool
+}
+
+// NewEtcdLeader campaigns for leadership against the etcd cluster at
+// endpoints and blocks until this replica wins or an error occurs.
+func NewEtcdLeader(endpoints []string) (Leader, error) {
+	client, err := clientv3.New(clientv3.Config{Endpoints: endpoints})
+	if err != nil {
+		return nil, err
+	}
+	session, err := concurrency.NewSession(client)
+	if err != nil {
+		return nil, err
+	}
+	election := concurrency.NewElection(session, etcdLeaderPrefix)
+	el := &etcdLeader{client: client, session: session, election: election, step: make(chan struct{})}
+	if err := election.Campaign(context.Background(), "member"); err != nil {
+		return nil, err
+	}
+	el.isLeader.Store(true)
+	go el.watch()
+	return el, nil
+}
+
+func (e *etcdLeader) watch() {
+	<-e.session.Done()
+	e.isLeader.Store(false)
+	select {
+	case e.step <- struct{}{}:
+	default:
+	}
+}
+
+func (e *etcdLeader) IsLeader() bool        { return e.isLeader.Load() }
+func (e *etcdLeader) Step() <-chan struct{} { return e.step }
+
+// Resign steps down from the election, first attempting a graceful
+// handoff so a waiting follower can campaign immediately rather than
+// waiting for this replica's session lease to expire.
+func (e *etcdLeader) Resign() error {
+	return attemptHandoff(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return e.election.Resign(ctx)
+	})
+}
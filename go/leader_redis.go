@@ -0,0 +1,90 @@
+//go:build redis
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisLeaderKey is the mutex name used for the leader election lock.
+const redisLeaderKey = "5calls:leader"
+
+// redisLeaseTTL is how long the lock is held before it must be renewed;
+// if the leader crashes without resigning, followers can promote after
+// this expires.
+const redisLeaseTTL = 15 * time.Second
+
+// redisLeader is a Leader backed by a Redis distributed lock (redsync):
+// the replica that acquires redisLeaderKey is the leader until it releases
+// the lock, fails to renew it in time, or calls Resign.
+type redisLeader struct {
+	mutex    *redsync.Mutex
+	step     chan struct{}
+	isLeader atomic.Bool
+	stop     chan struct{}
+}
+
+// NewRedisLeader attempts to acquire leadership against the Redis instance
+// at addr, retrying the renewal on redisLeaseTTL/2 for as long as this
+// replica remains the leader.
+func NewRedisLeader(addr string) (Leader, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	pool := goredis.NewPool(client)
+	rs := redsync.New(pool)
+	mutex := rs.NewMutex(redisLeaderKey, redsync.WithExpiry(redisLeaseTTL))
+
+	rl := &redisLeader{mutex: mutex, step: make(chan struct{}), stop: make(chan struct{})}
+	if err := mutex.Lock(); err != nil {
+		return nil, err
+	}
+	rl.isLeader.Store(true)
+	go rl.renew()
+	return rl, nil
+}
+
+func (r *redisLeader) renew() {
+	t := time.NewTicker(redisLeaseTTL / 2)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if _, err := r.mutex.Extend(); err != nil {
+				r.isLeader.Store(false)
+				select {
+				case r.step <- struct{}{}:
+				default:
+				}
+				return
+			}
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *redisLeader) IsLeader() bool        { return r.isLeader.Load() }
+func (r *redisLeader) Step() <-chan struct{} { return r.step }
+
+// Resign releases the Redis lock, first attempting a graceful handoff so a
+// waiting follower can acquire it immediately instead of waiting out
+// redisLeaseTTL. The stop channel is only ever closed once, after
+// attemptHandoff has finished retrying, so a transient error on an early
+// attempt can't make the retried closure close it twice.
+func (r *redisLeader) Resign() error {
+	err := attemptHandoff(func() error {
+		ok, unlockErr := r.mutex.Unlock()
+		if unlockErr == nil && !ok {
+			return context.DeadlineExceeded
+		}
+		return unlockErr
+	})
+	close(r.stop)
+	r.isLeader.Store(false)
+	return err
+}
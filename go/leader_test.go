@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeLeader is a controllable Leader for tests.
+type fakeLeader struct {
+	leading bool
+}
+
+func (f *fakeLeader) IsLeader() bool        { return f.leading }
+func (f *fakeLeader) Step() <-chan struct{} { return nil }
+func (f *fakeLeader) Resign() error         { return nil }
+
+// fakeFollowerSource returns canned data instead of calling Airtable, as a
+// follower would when fetching from the leader's snapshot endpoint.
+type fakeFollowerSource struct {
+	issues  []Issue
+	patches []Patch
+	err     error
+}
+
+func (f *fakeFollowerSource) FetchIssues() ([]Issue, error)  { return f.issues, f.err }
+func (f *fakeFollowerSource) FetchPatches() ([]Patch, error) { return f.patches, f.err }
+
+func TestSoloLeaderIsAlwaysLeader(t *testing.T) {
+	l := NewSoloLeader()
+	if !l.IsLeader() {
+		t.Fatal("soloLeader should always report leadership")
+	}
+	if err := l.Resign(); err != nil {
+		t.Fatalf("Resign on soloLeader should be a no-op, got %v", err)
+	}
+}
+
+func TestIsLeaderTreatsNilAsLeader(t *testing.T) {
+	if !isLeader(nil) {
+		t.Fatal("a nil Leader should be treated as always-leader")
+	}
+	if isLeader(&fakeLeader{leading: false}) {
+		t.Fatal("isLeader should defer to a non-nil Leader's IsLeader()")
+	}
+}
+
+func TestIssueCacheFollowerFetchesFromFollowerSource(t *testing.T) {
+	delegate := &fakeIssueLister{issues: []Issue{{ID: "from-delegate"}}}
+	follower := &fakeFollowerSource{issues: []Issue{{ID: "from-leader"}}}
+	leader := &fakeLeader{leading: false}
+
+	cache, err := NewIssueCache(delegate, time.Hour, "", leader, follower)
+	if err != nil {
+		t.Fatalf("NewIssueCache: %v", err)
+	}
+	ic := cache.(*issueCache)
+	defer ic.Close()
+
+	ic.Reload()
+	time.Sleep(50 * time.Millisecond)
+
+	got, err := cache.AllIssues()
+	if err != nil {
+		t.Fatalf("AllIssues: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "from-leader" {
+		t.Fatalf("expected follower data after reload, got %v", got)
+	}
+}
+
+func TestAttemptHandoffRetriesThenFallsBack(t *testing.T) {
+	tries := 0
+	failAlways := func() error {
+		tries++
+		return errors.New("no follower is ready")
+	}
+	if err := attemptHandoff(failAlways); err == nil {
+		t.Fatal("expected attemptHandoff to return the last error when every attempt fails")
+	}
+	if tries != handoffAttempts {
+		t.Fatalf("expected %d attempts, got %d", handoffAttempts, tries)
+	}
+}
+
+func TestAttemptHandoffSucceedsEarly(t *testing.T) {
+	tries := 0
+	succeedOnSecond := func() error {
+		tries++
+		if tries < 2 {
+			return errors.New("not yet")
+		}
+		return nil
+	}
+	if err := attemptHandoff(succeedOnSecond); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if tries != 2 {
+		t.Fatalf("expected attemptHandoff to stop retrying after success, got %d tries", tries)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "log/slog"
+
+// logOption carries a *slog.Logger through the functional options accepted
+// by NewAirtableClient, NewIssueCache, and NewContactPatcher, so callers
+// that want their own handler (e.g. to add request-scoped fields or ship
+// logs to a different sink) don't have to rely on slog.SetDefault.
+type logOption struct {
+	logger *slog.Logger
+}
+
+// WithLogger overrides the *slog.Logger used by the client or cache it's
+// passed to. Without it, slog.Default() is used.
+func WithLogger(logger *slog.Logger) logOption {
+	return logOption{logger: logger}
+}
+
+// resolveLogger returns the first non-nil logger among opts, falling back
+// to slog.Default().
+func resolveLogger(opts []logOption) *slog.Logger {
+	for _, o := range opts {
+		if o.logger != nil {
+			return o.logger
+		}
+	}
+	return slog.Default()
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestResolveLoggerDefaultsWhenNoOptions(t *testing.T) {
+	if resolveLogger(nil) != slog.Default() {
+		t.Fatal("expected resolveLogger to fall back to slog.Default()")
+	}
+}
+
+func TestWithLoggerOverridesDefault(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	got := resolveLogger([]logOption{WithLogger(logger)})
+	if got != logger {
+		t.Fatal("expected resolveLogger to return the logger passed to WithLogger")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Cache reload counters and Airtable call latency give operators enough to
+// alert on e.g. "the issues cache hasn't reloaded successfully in 10
+// minutes" - previously invisible since failures just logged and moved on.
+// The reload counters' "table" label holds the cache name ("issues" or
+// "patches"), not an Airtable table name; reloads are not counted per
+// Airtable table. Airtable call latency is labeled by Airtable table.
+var (
+	reloadAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fivecalls_cache_reload_attempts_total",
+		Help: "Number of cache reload attempts, by cache (issues or patches).",
+	}, []string{"table"})
+
+	reloadSuccessesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fivecalls_cache_reload_successes_total",
+		Help: "Number of cache reloads that completed without error, by cache (issues or patches).",
+	}, []string{"table"})
+
+	reloadFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fivecalls_cache_reload_failures_total",
+		Help: "Number of cache reloads that returned an error, by cache (issues or patches).",
+	}, []string{"table"})
+
+	airtableCallDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fivecalls_airtable_call_duration_seconds",
+		Help:    "Latency of calls to the Airtable API, by table.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"table"})
+
+	itemsCached = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fivecalls_cache_items",
+		Help: "Number of items currently held by a cache.",
+	}, []string{"cache"})
+
+	secondsSinceLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fivecalls_cache_seconds_since_last_success",
+		Help: "Seconds since a cache last reloaded successfully.",
+	}, []string{"cache"})
+)
+
+// MetricsHandler returns the handler to mount at /metrics.
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// observeAirtableCall records how long an Airtable call for table took.
+func observeAirtableCall(table string, start time.Time) {
+	airtableCallDurationSeconds.WithLabelValues(table).Observe(time.Since(start).Seconds())
+}
+
+// gaugeUpdater is the subset of issueCache/patchCache needed to keep the
+// items_cached and seconds_since_last_success gauges current.
+type gaugeUpdater interface {
+	ItemCount() int
+	LastSuccessAt() time.Time
+}
+
+// StartCacheGaugeUpdater periodically refreshes the items_cached and
+// seconds_since_last_success gauges for cache (labeled as name, e.g.
+// "issues" or "patches"). It returns a func that stops the updater.
+func StartCacheGaugeUpdater(name string, cache gaugeUpdater, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	t := time.NewTicker(interval)
+	go func() {
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				itemsCached.WithLabelValues(name).Set(float64(cache.ItemCount()))
+				if last := cache.LastSuccessAt(); !last.IsZero() {
+					secondsSinceLastSuccess.WithLabelValues(name).Set(time.Since(last).Seconds())
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// reloader is the subset of issueCache/patchCache that a Notifier needs in
+// order to force a targeted refresh.
+type reloader interface {
+	Reload()
+}
+
+// tableReloaders routes a table name to the cache(s) that hold data from it.
+type tableReloaders map[string]reloader
+
+// NewTableReloaders builds the table->cache routing shared by the HTTP
+// notify handler and the AMQP/Redis Notifier implementations: issues and
+// contacts both live behind issueCache, while additions/deletions live
+// behind patchCache.
+func NewTableReloaders(issues reloader, patches reloader) tableReloaders {
+	return tableReloaders{
+		issuesTable:    issues,
+		contactsTable:  issues,
+		additionsTable: patches,
+		deletionsTable: patches,
+	}
+}
+
+// Notifier delivers table-change events from an external system so a cache
+// can be invalidated the moment the underlying data changes instead of
+// waiting for its next scheduled poll. The Notifier implementations are the
+// pub/sub ones in notifier_amqp.go and notifier_redis.go (built with the
+// matching build tag); the HTTP webhook below, NotifyHandler, is a plain
+// http.Handler and does not implement Notifier.
+type Notifier interface {
+	// Listen connects to the backing broker and returns once subscribed,
+	// dispatching a Reload to the affected cache in the background for
+	// every change event it receives until Close is called. It does not
+	// block the caller.
+	Listen(reloaders tableReloaders) error
+	Close() error
+}
+
+// notifyPayload is the JSON body accepted by the HTTP notify endpoint and
+// published on the AMQP/Redis backends.
+type notifyPayload struct {
+	Table string `json:"table"`
+}
+
+// maxNotifyBodyBytes caps the size of an inbound /admin/notify request so a
+// misbehaving sender can't tie up a connection streaming a large body.
+const maxNotifyBodyBytes = 1 << 16
+
+// NotifyHandler returns an http.Handler for POST /admin/notify: it verifies
+// the request was signed with secret, looks up the affected cache in
+// reloaders, and forces a reload of it. Signature verification is skipped
+// when secret is empty, which is convenient for local dev but should never
+// happen in production.
+func NotifyHandler(secret []byte, reloaders tableReloaders) http.Handler {
+	n := &httpNotifier{secret: secret, reloaders: reloaders, limiters: map[string]*rate.Limiter{}}
+	return http.HandlerFunc(n.serveHTTP)
+}
+
+// httpNotifier holds the state behind NotifyHandler: a per-table rate
+// limiter so a compromised or buggy sender can't force-reload a table fast
+// enough to burn through the Airtable API quota.
+type httpNotifier struct {
+	secret    []byte
+	reloaders tableReloaders
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func (n *httpNotifier) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxNotifyBodyBytes))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+	if !n.verify(r.Header.Get("X-5calls-Signature"), body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	var payload notifyPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	n.handle(payload.Table)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verify reports whether signature is the hex-encoded HMAC-SHA256 of body
+// under n.secret.
+func (n *httpNotifier) verify(signature string, body []byte) bool {
+	if len(n.secret) == 0 {
+		return true
+	}
+	mac := hmac.New(sha256.New, n.secret)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+func (n *httpNotifier) handle(table string) {
+	cache, ok := n.reloaders[table]
+	if !ok {
+		log.Println("[WARN] notify received for unknown table", table)
+		return
+	}
+	if !n.allow(table) {
+		log.Println("[WARN] dropping notify for", table, "- rate limit exceeded")
+		return
+	}
+	cache.Reload()
+}
+
+// allow reports whether table is still within its rate limit, creating a
+// limiter for it on first use. One forced reload per second per table is
+// already far more than Airtable's data changes in practice.
+func (n *httpNotifier) allow(table string) bool {
+	n.mu.Lock()
+	l, ok := n.limiters[table]
+	if !ok {
+		l = rate.NewLimiter(rate.Every(time.Second), 1)
+		n.limiters[table] = l
+	}
+	n.mu.Unlock()
+	return l.Allow()
+}
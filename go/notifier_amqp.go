@@ -0,0 +1,88 @@
+//go:build amqp
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/streadway/amqp"
+)
+
+// amqpNotifier is a Notifier backed by an AMQP topic exchange, for
+// deployments that already run RabbitMQ and would rather publish a change
+// event there than manage an HTTP webhook.
+type amqpNotifier struct {
+	URL      string
+	Exchange string
+
+	conn *amqp.Connection
+	ch   *amqp.Channel
+	done chan struct{}
+}
+
+// NewAMQPNotifier returns a Notifier that consumes table-change events from
+// exchange on the AMQP broker at url.
+func NewAMQPNotifier(url, exchange string) *amqpNotifier {
+	return &amqpNotifier{URL: url, Exchange: exchange, done: make(chan struct{})}
+}
+
+func (n *amqpNotifier) Listen(reloaders tableReloaders) error {
+	conn, err := amqp.Dial(n.URL)
+	if err != nil {
+		return err
+	}
+	n.conn = conn
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	n.ch = ch
+	if err := ch.ExchangeDeclare(n.Exchange, "fanout", true, false, false, false, nil); err != nil {
+		return err
+	}
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return err
+	}
+	if err := ch.QueueBind(q.Name, "", n.Exchange, false, nil); err != nil {
+		return err
+	}
+	msgs, err := ch.Consume(q.Name, "", true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for {
+			select {
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				var payload notifyPayload
+				if err := json.Unmarshal(msg.Body, &payload); err != nil {
+					log.Println("[WARN] unable to decode amqp notify payload,", err)
+					continue
+				}
+				if cache, ok := reloaders[payload.Table]; ok {
+					cache.Reload()
+				}
+			case <-n.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (n *amqpNotifier) Close() error {
+	close(n.done)
+	if n.ch != nil {
+		n.ch.Close()
+	}
+	if n.conn != nil {
+		return n.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+//go:build redis
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisNotifyChannel is the pub/sub channel table-change events are
+// published on.
+const redisNotifyChannel = "5calls:notify"
+
+// redisNotifier is a Notifier backed by Redis pub/sub, for deployments that
+// already run Redis and would rather publish a change event there than
+// manage an HTTP webhook.
+type redisNotifier struct {
+	Addr string
+
+	client *redis.Client
+	cancel context.CancelFunc
+}
+
+// NewRedisNotifier returns a Notifier that subscribes to redisNotifyChannel
+// on the Redis instance at addr.
+func NewRedisNotifier(addr string) *redisNotifier {
+	return &redisNotifier{Addr: addr}
+}
+
+func (n *redisNotifier) Listen(reloaders tableReloaders) error {
+	n.client = redis.NewClient(&redis.Options{Addr: n.Addr})
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	sub := n.client.Subscribe(ctx, redisNotifyChannel)
+	// Subscribe doesn't wait for Redis to confirm; Receive does, so Listen
+	// only returns once the subscription is active.
+	if _, err := sub.Receive(ctx); err != nil {
+		cancel()
+		return err
+	}
+	ch := sub.Channel()
+	go func() {
+		for msg := range ch {
+			var payload notifyPayload
+			if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil {
+				log.Println("[WARN] unable to decode redis notify payload,", err)
+				continue
+			}
+			if cache, ok := reloaders[payload.Table]; ok {
+				cache.Reload()
+			}
+		}
+	}()
+	return nil
+}
+
+func (n *redisNotifier) Close() error {
+	if n.cancel != nil {
+		n.cancel()
+	}
+	if n.client != nil {
+		return n.client.Close()
+	}
+	return nil
+}
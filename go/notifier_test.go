@@ -0,0 +1,152 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeReloader is a controllable reloader for tests.
+type fakeReloader struct {
+	calls int
+}
+
+func (f *fakeReloader) Reload() { f.calls++ }
+
+func sign(secret []byte, body string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNotifyHandlerAcceptsValidSignature(t *testing.T) {
+	secret := []byte("shh")
+	issues := &fakeReloader{}
+	handler := NotifyHandler(secret, NewTableReloaders(issues, &fakeReloader{}))
+
+	body := `{"table":"Issues list"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/notify", strings.NewReader(body))
+	req.Header.Set("X-5calls-Signature", sign(secret, body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+	if issues.calls != 1 {
+		t.Fatalf("expected Reload to be called once, got %d", issues.calls)
+	}
+}
+
+func TestNotifyHandlerRejectsInvalidSignature(t *testing.T) {
+	secret := []byte("shh")
+	issues := &fakeReloader{}
+	handler := NotifyHandler(secret, NewTableReloaders(issues, &fakeReloader{}))
+
+	body := `{"table":"Issues list"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/notify", strings.NewReader(body))
+	req.Header.Set("X-5calls-Signature", sign(secret, "tampered body"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if issues.calls != 0 {
+		t.Fatal("Reload should not be called for an invalid signature")
+	}
+}
+
+func TestNotifyHandlerRejectsMissingSignature(t *testing.T) {
+	secret := []byte("shh")
+	issues := &fakeReloader{}
+	handler := NotifyHandler(secret, NewTableReloaders(issues, &fakeReloader{}))
+
+	body := `{"table":"Issues list"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/notify", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if issues.calls != 0 {
+		t.Fatal("Reload should not be called without a signature")
+	}
+}
+
+func TestNotifyHandlerSkipsVerificationWithEmptySecret(t *testing.T) {
+	issues := &fakeReloader{}
+	handler := NotifyHandler(nil, NewTableReloaders(issues, &fakeReloader{}))
+
+	body := `{"table":"Issues list"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/notify", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+	if issues.calls != 1 {
+		t.Fatalf("expected Reload to be called once, got %d", issues.calls)
+	}
+}
+
+func TestNotifyHandlerIgnoresUnknownTable(t *testing.T) {
+	secret := []byte("shh")
+	issues := &fakeReloader{}
+	handler := NotifyHandler(secret, NewTableReloaders(issues, &fakeReloader{}))
+
+	body := `{"table":"Nonexistent"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/notify", strings.NewReader(body))
+	req.Header.Set("X-5calls-Signature", sign(secret, body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 even for an unknown table, got %d", w.Code)
+	}
+	if issues.calls != 0 {
+		t.Fatal("Reload should not be called for an unknown table")
+	}
+}
+
+func TestNotifyHandlerDropsNotifyOverRateLimit(t *testing.T) {
+	secret := []byte("shh")
+	issues := &fakeReloader{}
+	handler := NotifyHandler(secret, NewTableReloaders(issues, &fakeReloader{}))
+
+	body := `{"table":"Issues list"}`
+	signature := sign(secret, body)
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/admin/notify", strings.NewReader(body))
+		req.Header.Set("X-5calls-Signature", signature)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := send(); code != http.StatusAccepted {
+		t.Fatalf("expected first notify to be accepted, got %d", code)
+	}
+	if code := send(); code != http.StatusAccepted {
+		t.Fatalf("expected second notify to still be accepted (just not reloaded), got %d", code)
+	}
+
+	if issues.calls != 1 {
+		t.Fatalf("expected the second, rate-limited notify to be dropped without a Reload, got %d calls", issues.calls)
+	}
+
+	time.Sleep(time.Second)
+	if code := send(); code != http.StatusAccepted {
+		t.Fatalf("expected notify to be accepted again after the limiter replenishes, got %d", code)
+	}
+	if issues.calls != 2 {
+		t.Fatalf("expected Reload to fire again after the limiter replenished, got %d calls", issues.calls)
+	}
+}
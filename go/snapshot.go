@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// FollowerSource lets a non-leader replica obtain the data the current
+// leader already fetched from Airtable, instead of calling Airtable
+// itself. The default implementation, httpFollowerSource, does this by
+// calling the leader's internal snapshot endpoints below.
+type FollowerSource interface {
+	FetchIssues() ([]Issue, error)
+	FetchPatches() ([]Patch, error)
+}
+
+// SnapshotHandler serves GET /internal/snapshot/issues and
+// GET /internal/snapshot/patches with the current contents of ic/pc, so
+// followers can fetch already-loaded data without hitting Airtable
+// themselves. It is only meant to be reached
+// over the internal network, not exposed publicly.
+func SnapshotHandler(ic *issueCache, pc *patchCache) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/internal/snapshot/issues", func(w http.ResponseWriter, r *http.Request) {
+		issues, _ := ic.AllIssues()
+		writeSnapshot(w, issues)
+	})
+	mux.HandleFunc("/internal/snapshot/patches", func(w http.ResponseWriter, r *http.Request) {
+		patches, _ := pc.AllPatches()
+		writeSnapshot(w, patches)
+	})
+	return mux
+}
+
+func writeSnapshot(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("[WARN] unable to encode snapshot response,", err)
+	}
+}
+
+// httpFollowerSource is the default FollowerSource: it fetches the leader's
+// snapshot endpoints over plain HTTP.
+type httpFollowerSource struct {
+	// LeaderAddr returns the base URL (scheme://host:port) of the current
+	// leader. It's a func rather than a fixed string because leadership -
+	// and therefore the address to call - can change at any time.
+	LeaderAddr func() string
+	client     *http.Client
+}
+
+// NewHTTPFollowerSource returns a FollowerSource that asks leaderAddr for
+// the current leader's address before every fetch.
+func NewHTTPFollowerSource(leaderAddr func() string) FollowerSource {
+	return &httpFollowerSource{LeaderAddr: leaderAddr, client: http.DefaultClient}
+}
+
+func (f *httpFollowerSource) FetchIssues() ([]Issue, error) {
+	var issues []Issue
+	if err := f.fetch("/internal/snapshot/issues", &issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+func (f *httpFollowerSource) FetchPatches() ([]Patch, error) {
+	var patches []Patch
+	if err := f.fetch("/internal/snapshot/patches", &patches); err != nil {
+		return nil, err
+	}
+	return patches, nil
+}
+
+func (f *httpFollowerSource) fetch(path string, out interface{}) error {
+	addr := f.LeaderAddr()
+	if addr == "" {
+		return fmt.Errorf("no known leader address")
+	}
+	resp, err := f.client.Get(addr + path)
+	if err != nil {
+		return fmt.Errorf("fetching %s from leader: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("leader returned %s: %s", resp.Status, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}